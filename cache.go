@@ -1,41 +1,93 @@
 package lockotron
 
 import (
+	"context"
 	"errors"
+	"math/rand"
+	"os"
 	"sync"
 	"time"
 )
 
 var (
 	ErrNotFound = errors.New("cached value not found")
+
+	// ErrCacheNegative is returned by a Fetch fallback to signal that the
+	// key is legitimately absent upstream. Cache then caches a negative
+	// placeholder for Config.NegativeTTL instead of calling the fallback
+	// again on every subsequent miss.
+	ErrCacheNegative = errors.New("cached value does not exist upstream")
 )
 
 type Cache struct {
 	locker   *locker
-	mutex    sync.RWMutex
-	items    map[string]*item
+	store    Store
+	tracker  evictionTracker
 	stopChan chan bool
 	ticker   *time.Ticker
 	config   *Config
+
+	sizeMutex sync.Mutex
+	sizes     map[string]int
+	bytes     int64
+
+	counters        counters
+	fallbackLatency *histogram
 }
 
 func NewCache(config *Config) *Cache {
 	c := &Cache{
-		locker: newLocker(),
-		items:  make(map[string]*item),
-		config: config,
+		locker:          newLocker(),
+		store:           config.store(),
+		config:          config,
+		sizes:           make(map[string]int),
+		fallbackLatency: newHistogram(defaultLatencyBuckets),
+	}
+
+	if config.EvictionPolicy != EvictionPolicyNone {
+		c.tracker = newEvictionTracker(config.EvictionPolicy, config.MaxItems)
+	}
+
+	if config.SnapshotPath != "" {
+		if f, err := os.Open(config.SnapshotPath); err == nil {
+			_ = c.LoadSnapshot(f)
+			f.Close()
+		}
 	}
 
-	if config.CleanupInterval != NoCleanup {
-		c.ticker = time.NewTicker(config.CleanupInterval)
+	needsCleanup := config.CleanupInterval != NoCleanup
+	needsSnapshots := config.SnapshotPath != "" && config.SnapshotInterval > 0
+
+	if needsCleanup || needsSnapshots {
+		c.stopChan = make(chan bool)
+
+		var cleanupC <-chan time.Time
+		if needsCleanup {
+			c.ticker = time.NewTicker(config.CleanupInterval)
+			cleanupC = c.ticker.C
+		}
+
+		var snapshotTicker *time.Ticker
+		var snapshotC <-chan time.Time
+		if needsSnapshots {
+			snapshotTicker = time.NewTicker(config.SnapshotInterval)
+			snapshotC = snapshotTicker.C
+		}
 
 		go func() {
 			for {
 				select {
-				case <-c.ticker.C:
+				case <-cleanupC:
 					c.DeleteExpired()
+				case <-snapshotC:
+					c.saveSnapshotToPath()
 				case <-c.stopChan:
-					c.ticker.Stop()
+					if c.ticker != nil {
+						c.ticker.Stop()
+					}
+					if snapshotTicker != nil {
+						snapshotTicker.Stop()
+					}
 
 					return
 				}
@@ -47,7 +99,7 @@ func NewCache(config *Config) *Cache {
 }
 
 func (c *Cache) Close() error {
-	if c.stopChan == nil || c.ticker == nil {
+	if c.stopChan == nil {
 		return nil
 	}
 
@@ -61,11 +113,9 @@ func (c *Cache) Set(key string, value interface{}) {
 }
 
 func (c *Cache) SetList(list map[string]interface{}) {
-	c.mutex.Lock()
 	for key, value := range list {
-		c.items[key] = newItem(value, c.config.DefaultTTL)
+		c.set(key, c.config.DefaultTTL, value)
 	}
-	c.mutex.Unlock()
 }
 
 func (c *Cache) SetEx(key string, ttl time.Duration, value interface{}) {
@@ -73,101 +123,318 @@ func (c *Cache) SetEx(key string, ttl time.Duration, value interface{}) {
 }
 
 func (c *Cache) Get(key string) (interface{}, error) {
-	c.mutex.RLock()
-	item, ok := c.items[key]
-	c.mutex.RUnlock()
-	if ok {
-		return item.value, nil
+	value, _, err := c.lookup(key)
+
+	return value, err
+}
+
+// lookup is Get's implementation, plus it reports whether a miss is a
+// negative-cached placeholder rather than a true absence, so fetch() can
+// short-circuit a negative hit straight to ErrNotFound instead of
+// re-entering the lock/fallback path on every subsequent call.
+func (c *Cache) lookup(key string) (value interface{}, negative bool, err error) {
+	storeKey := c.config.key(key)
+
+	it, ok, err := c.store.Get(storeKey)
+	if err != nil || !ok || it.negative {
+		c.counters.miss()
+		if c.config.Observer != nil {
+			c.config.Observer.OnMiss(key)
+		}
+
+		if err != nil {
+			return nil, false, err
+		}
+
+		return nil, ok && it.negative, ErrNotFound
 	}
 
-	return nil, ErrNotFound
+	if c.tracker != nil {
+		c.tracker.touch(storeKey)
+	}
+
+	c.counters.hit()
+	if c.config.Observer != nil {
+		c.config.Observer.OnHit(key)
+	}
+
+	return it.value, false, nil
 }
 
 func (c *Cache) GetList(keys []string) []interface{} {
 	values := make([]interface{}, 0, len(keys))
 
-	c.mutex.RLock()
 	for _, key := range keys {
-		item, ok := c.items[key]
-		if ok {
-			values = append(values, item.value)
+		value, err := c.Get(key)
+		if err == nil {
+			values = append(values, value)
 		}
 	}
-	c.mutex.RUnlock()
 
 	return values
 }
 
 func (c *Cache) Delete(key string) {
-	c.mutex.Lock()
-	delete(c.items, key)
-	c.mutex.Unlock()
+	storeKey := c.config.key(key)
+
+	_ = c.store.Delete(storeKey)
+	c.forget(storeKey)
 }
 
-func (c *Cache) Fetch(key string, fallback func(string) (interface{}, error)) (interface{}, error) {
-	return c.fetch(key, c.config.DefaultTTL, fallback)
+func (c *Cache) Fetch(ctx context.Context, key string, fallback func(string) (interface{}, error)) (interface{}, error) {
+	return c.fetch(ctx, key, c.config.DefaultTTL, fallback)
 }
 
-func (c *Cache) FetchEx(key string, ttl time.Duration, fallback func(string) (interface{}, error)) (interface{}, error) {
-	return c.fetch(key, ttl, fallback)
+func (c *Cache) FetchEx(ctx context.Context, key string, ttl time.Duration, fallback func(string) (interface{}, error)) (interface{}, error) {
+	return c.fetch(ctx, key, ttl, fallback)
 }
 
 func (c *Cache) DeleteAll() {
-	c.mutex.Lock()
-	c.items = make(map[string]*item)
-	c.mutex.Unlock()
+	keys := make([]string, 0)
+	_ = c.store.Iterate(func(key string, it *item) bool {
+		keys = append(keys, key)
+
+		return true
+	})
+
+	for _, key := range keys {
+		_ = c.store.Delete(key)
+		c.forget(key)
+	}
 }
 
 func (c *Cache) DeleteExpired() {
 	now := time.Now().UnixNano()
 
-	c.mutex.Lock()
-	for key, item := range c.items {
-		if item.isExpirable() && now > item.ttl {
-			delete(c.items, key)
+	expired := make([]string, 0)
+	_ = c.store.Iterate(func(key string, it *item) bool {
+		if it.isExpirable() && now > it.ttl {
+			expired = append(expired, key)
+		}
+
+		return true
+	})
+
+	for _, key := range expired {
+		it, found, _ := c.store.Get(key)
+		_ = c.store.Delete(key)
+		c.forget(key)
+
+		if found {
+			c.notifyEvict(key, it.value, EvictReasonExpired)
 		}
 	}
-	c.mutex.Unlock()
 }
 
 func (c *Cache) DeleteList(keys []string) {
-	c.mutex.Lock()
 	for _, key := range keys {
-		delete(c.items, key)
+		storeKey := c.config.key(key)
+
+		_ = c.store.Delete(storeKey)
+		c.forget(storeKey)
 	}
-	c.mutex.Unlock()
 }
 
-func (c *Cache) fetch(key string, ttl time.Duration, fallback func(string) (interface{}, error)) (interface{}, error) {
-	value, err := c.Get(key)
+func (c *Cache) fetch(ctx context.Context, key string, ttl time.Duration, fallback func(string) (interface{}, error)) (interface{}, error) {
+	value, negative, err := c.lookup(key)
 	if err == nil {
 		return value, nil
 	}
+	if negative {
+		return nil, ErrNotFound
+	}
 
-	mutex := c.locker.obtain(key)
-	mutex.Lock()
-	defer mutex.Unlock()
+	entry := c.locker.obtain(key)
 	defer c.locker.release(key)
 
-	value, err = c.Get(key)
+	generation, err := entry.acquire(ctx, c.config.FallbackTimeout, c.config.LockLeaseInterval)
+	if err != nil {
+		return nil, err
+	}
+	defer entry.release(generation)
+
+	value, negative, err = c.lookup(key)
 	if err == nil {
+		c.counters.coalescedWait()
+
 		return value, nil
 	}
+	if negative {
+		return nil, ErrNotFound
+	}
 
+	c.counters.fallbackCall()
+	start := time.Now()
 	value, err = fallback(key)
+	duration := time.Since(start)
+
+	c.fallbackLatency.observe(duration.Seconds())
+	if c.config.Observer != nil {
+		c.config.Observer.OnFallback(duration, err)
+	}
+
 	if err != nil {
+		if errors.Is(err, ErrCacheNegative) && c.config.NegativeTTL > 0 {
+			// Only write the negative placeholder if our lease is still
+			// current: if it was stolen, a newer holder may already own
+			// this key's cached state and our result must not clobber it.
+			if entry.stillOwns(generation) {
+				c.setNegative(key)
+			}
+
+			return nil, ErrNotFound
+		}
+
+		c.counters.fallbackError()
+
 		return nil, err
 	}
 
-	c.SetEx(key, ttl, value)
+	// Same as above: a displaced holder's fallback result is returned to
+	// its own caller, but not persisted, since it may be stale relative
+	// to whatever the holder that stole the lease has already written.
+	if entry.stillOwns(generation) {
+		c.SetEx(key, ttl, value)
+	}
 
 	return value, nil
 }
 
 func (c *Cache) set(key string, ttl time.Duration, value interface{}) {
-	c.mutex.Lock()
-	c.items[key] = newItem(value, ttl)
-	c.mutex.Unlock()
+	c.setItem(key, newItem(value, c.jitter(ttl)))
+}
+
+func (c *Cache) setNegative(key string) {
+	c.setItem(key, newNegativeItem(c.jitter(c.config.NegativeTTL)))
+}
+
+func (c *Cache) setItem(key string, it *item) {
+	storeKey := c.config.key(key)
+
+	_ = c.store.Set(storeKey, it)
+	c.remember(storeKey, it.value)
+
+	if c.tracker != nil {
+		c.tracker.add(storeKey)
+		c.enforceCapacity()
+	}
+}
+
+func (c *Cache) jitter(ttl time.Duration) time.Duration {
+	if ttl <= 0 || c.config.TTLDeviation <= 0 {
+		return ttl
+	}
+
+	d := c.config.TTLDeviation
+	factor := 1 + rand.Float64()*d - d/2
+
+	return time.Duration(float64(ttl) * factor)
+}
+
+func (c *Cache) enforceCapacity() {
+	for c.overCapacity() {
+		key, ok := c.tracker.victim()
+		if !ok {
+			return
+		}
+
+		it, found, _ := c.store.Get(key)
+		_ = c.store.Delete(key)
+		// victim() already updated the tracker itself: for ARC it
+		// relocates the key into a ghost list rather than fully
+		// removing it, so forget's tracker.remove must be skipped
+		// here or it would immediately erase that ghost entry.
+		c.forgetSize(key)
+
+		if found {
+			c.notifyEvict(key, it.value, EvictReasonCapacity)
+		}
+	}
+}
+
+func (c *Cache) notifyEvict(key string, value interface{}, reason EvictReason) {
+	c.counters.evicted(reason)
+
+	if c.config.OnEvict != nil {
+		c.config.OnEvict(key, value, reason)
+	}
+
+	if c.config.Observer != nil {
+		c.config.Observer.OnEvict(key, value, reason)
+	}
+}
+
+// Stats returns a point-in-time snapshot of this Cache's activity
+// counters and fallback latency histogram.
+func (c *Cache) Stats() Stats {
+	stats := c.counters.snapshot()
+	stats.ItemCount, _ = c.store.Len()
+	stats.FallbackLatency = c.fallbackLatency.snapshot()
+
+	return stats
+}
+
+func (c *Cache) overCapacity() bool {
+	if c.config.MaxItems > 0 && c.tracker.len() > c.config.MaxItems {
+		return true
+	}
+
+	if c.config.MaxBytes > 0 && c.currentBytes() > c.config.MaxBytes {
+		return true
+	}
+
+	return false
+}
+
+func (c *Cache) remember(key string, value interface{}) {
+	if c.config.Sizer == nil {
+		return
+	}
+
+	size := c.config.Sizer(value)
+
+	c.sizeMutex.Lock()
+	defer c.sizeMutex.Unlock()
+
+	if old, ok := c.sizes[key]; ok {
+		c.bytes -= int64(old)
+	}
+
+	c.sizes[key] = size
+	c.bytes += int64(size)
+}
+
+func (c *Cache) forget(key string) {
+	if c.tracker != nil {
+		c.tracker.remove(key)
+	}
+
+	c.forgetSize(key)
+}
+
+// forgetSize drops key's size bookkeeping without touching the tracker.
+// Used after a capacity eviction, where tracker.victim() has already
+// applied the correct tracker-side update itself (for ARC, a relocation
+// into a ghost list rather than an outright removal).
+func (c *Cache) forgetSize(key string) {
+	if c.config.Sizer == nil {
+		return
+	}
+
+	c.sizeMutex.Lock()
+	defer c.sizeMutex.Unlock()
+
+	if old, ok := c.sizes[key]; ok {
+		c.bytes -= int64(old)
+		delete(c.sizes, key)
+	}
+}
+
+func (c *Cache) currentBytes() int64 {
+	c.sizeMutex.Lock()
+	defer c.sizeMutex.Unlock()
+
+	return c.bytes
 }
 
 func IsNotFoundErr(err error) bool {
@@ -0,0 +1,97 @@
+package lockotron
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFetchStealsLockFromAHungFallback(t *testing.T) {
+	c := NewCache(&Config{
+		DefaultTTL:        time.Minute,
+		FallbackTimeout:   30 * time.Millisecond,
+		LockLeaseInterval: 5 * time.Millisecond,
+	})
+
+	hang := make(chan struct{})
+	defer close(hang)
+
+	started := make(chan struct{}, 2)
+	go func() {
+		_, _ = c.Fetch(context.Background(), "k", func(string) (interface{}, error) {
+			started <- struct{}{}
+			<-hang // never returns within the test
+
+			return "first", nil
+		})
+	}()
+	<-started
+
+	var secondCalled int32
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	value, err := c.Fetch(ctx, "k", func(string) (interface{}, error) {
+		atomic.AddInt32(&secondCalled, 1)
+
+		return "second", nil
+	})
+	if err != nil {
+		t.Fatalf("expected the second Fetch to steal the lease and succeed, got %v", err)
+	}
+	if value != "second" {
+		t.Fatalf("expected %q, got %v", "second", value)
+	}
+	if atomic.LoadInt32(&secondCalled) != 1 {
+		t.Fatalf("expected the second fallback to run exactly once, ran %d times", secondCalled)
+	}
+}
+
+func TestFetchDoesNotClobberAFresherValueAfterASteal(t *testing.T) {
+	c := NewCache(&Config{
+		DefaultTTL:        time.Minute,
+		FallbackTimeout:   20 * time.Millisecond,
+		LockLeaseInterval: 5 * time.Millisecond,
+	})
+
+	firstStarted := make(chan struct{})
+	firstDone := make(chan struct{})
+
+	go func() {
+		defer close(firstDone)
+
+		_, _ = c.Fetch(context.Background(), "k", func(string) (interface{}, error) {
+			close(firstStarted)
+			// Slow, not hung: it outlives FallbackTimeout and its lease
+			// gets stolen, but it still eventually returns.
+			time.Sleep(80 * time.Millisecond)
+
+			return "stale-from-first", nil
+		})
+	}()
+	<-firstStarted
+
+	// Give the first holder's lease time to expire before stealing it.
+	time.Sleep(30 * time.Millisecond)
+
+	value, err := c.Fetch(context.Background(), "k", func(string) (interface{}, error) {
+		return "fresh-from-second", nil
+	})
+	if err != nil {
+		t.Fatalf("second Fetch: %v", err)
+	}
+	if value != "fresh-from-second" {
+		t.Fatalf("expected %q, got %v", "fresh-from-second", value)
+	}
+
+	<-firstDone // wait for the displaced holder's fallback to finish and (attempt to) write back
+
+	got, err := c.Get("k")
+	if err != nil {
+		t.Fatalf("Get after first holder's write-back attempt: %v", err)
+	}
+	if got != "fresh-from-second" {
+		t.Fatalf("expected the displaced holder's stale write to be discarded, got %v", got)
+	}
+}
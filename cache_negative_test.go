@@ -0,0 +1,49 @@
+package lockotron
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFetchShortCircuitsOnNegativeHit(t *testing.T) {
+	c := NewCache(&Config{
+		DefaultTTL:  time.Minute,
+		NegativeTTL: time.Minute,
+	})
+
+	var calls int32
+	fallback := func(string) (interface{}, error) {
+		atomic.AddInt32(&calls, 1)
+
+		return nil, ErrCacheNegative
+	}
+
+	if _, err := c.Fetch(context.Background(), "k", fallback); !IsNotFoundErr(err) {
+		t.Fatalf("expected ErrNotFound from the negative fallback, got %v", err)
+	}
+	if atomic.LoadInt32(&calls) != 1 {
+		t.Fatalf("expected 1 fallback call, got %d", calls)
+	}
+
+	if _, err := c.Fetch(context.Background(), "k", fallback); !IsNotFoundErr(err) {
+		t.Fatalf("expected a cached negative hit to still report ErrNotFound, got %v", err)
+	}
+	if atomic.LoadInt32(&calls) != 1 {
+		t.Fatalf("expected the negative cache hit to short-circuit without re-running the fallback, ran %d times", calls)
+	}
+}
+
+func TestJitterStaysWithinDeviation(t *testing.T) {
+	c := NewCache(&Config{TTLDeviation: 0.5})
+
+	const ttl = 100 * time.Millisecond
+
+	for i := 0; i < 50; i++ {
+		jittered := c.jitter(ttl)
+		if jittered < 75*time.Millisecond || jittered > 125*time.Millisecond {
+			t.Fatalf("jittered TTL %v out of +/-25%% bounds around %v", jittered, ttl)
+		}
+	}
+}
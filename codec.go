@@ -0,0 +1,26 @@
+package lockotron
+
+import "encoding/json"
+
+// Codec serializes values for Store implementations that can only hold
+// bytes, such as the Redis and Memcached drivers. The in-memory store
+// does not use a Codec since it keeps values as native Go interfaces.
+type Codec interface {
+	Encode(value interface{}) ([]byte, error)
+	Decode(data []byte) (interface{}, error)
+}
+
+type JSONCodec struct{}
+
+func (JSONCodec) Encode(value interface{}) ([]byte, error) {
+	return json.Marshal(value)
+}
+
+func (JSONCodec) Decode(data []byte) (interface{}, error) {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
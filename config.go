@@ -0,0 +1,102 @@
+package lockotron
+
+import "time"
+
+const NoCleanup = time.Duration(0)
+
+type Config struct {
+	DefaultTTL      time.Duration
+	CleanupInterval time.Duration
+
+	// Store holds cached items. It defaults to an in-memory map when left
+	// nil, but can be swapped for a Redis- or Memcached-backed Store to use
+	// lockotron as a thundering-herd guard in front of a shared cache.
+	Store Store
+
+	// KeyFunc, when set, transforms every key before it reaches Store,
+	// e.g. to namespace keys per tenant.
+	KeyFunc func(string) string
+
+	// MaxItems bounds the number of items Cache keeps; once exceeded,
+	// EvictionPolicy picks a victim on the next Set. Zero means unbounded.
+	MaxItems int
+
+	// MaxBytes bounds the total size of cached values as reported by
+	// Sizer; once exceeded, EvictionPolicy picks a victim on the next
+	// Set. Zero means unbounded.
+	MaxBytes int64
+	Sizer    func(interface{}) int
+
+	// EvictionPolicy selects the capacity-eviction algorithm. It defaults
+	// to EvictionPolicyNone, under which MaxItems/MaxBytes are ignored.
+	EvictionPolicy EvictionPolicy
+
+	// OnEvict, when set, is called whenever an item is removed due to
+	// capacity eviction or TTL expiry.
+	OnEvict func(key string, value interface{}, reason EvictReason)
+
+	// TTLDeviation adds up to +/-deviation/2 random jitter to every TTL
+	// (e.g. 0.05 for +/-2.5%) so bulk-warmed keys don't expire at the same
+	// instant and stampede the fallback. Zero disables jitter.
+	TTLDeviation float64
+
+	// NegativeTTL, when set, is the TTL used to cache a "not found"
+	// placeholder whenever a Fetch fallback returns ErrCacheNegative.
+	// Zero disables negative caching; the fallback's error is returned
+	// as-is instead.
+	NegativeTTL time.Duration
+
+	// FallbackTimeout bounds how long a single Fetch call may hold the
+	// per-key lock while its fallback runs. The lease is granted once,
+	// for this long, and is never extended: once it expires, another
+	// waiter is allowed to force the lock and run the fallback itself,
+	// even if the original call's fallback is still in flight (e.g.
+	// genuinely hung on a dead upstream rather than merely slow). Zero
+	// means the lock is held until release, i.e. a hung fallback blocks
+	// the key forever.
+	FallbackTimeout time.Duration
+
+	// LockLeaseInterval is how often a waiter blocked on a held lock
+	// rechecks whether FallbackTimeout has expired. It defaults to a
+	// small internal interval when zero, and is ignored when
+	// FallbackTimeout is zero.
+	LockLeaseInterval time.Duration
+
+	// Observer, when set, is notified of hits, misses, fallback calls,
+	// and evictions as they happen, in addition to Cache.Stats().
+	Observer Observer
+
+	// SnapshotPath, when set, is hydrated into the Cache on NewCache (if
+	// present) and periodically overwritten with the current contents
+	// every SnapshotInterval, by the same goroutine that runs
+	// DeleteExpired.
+	SnapshotPath     string
+	SnapshotInterval time.Duration
+
+	// SnapshotCodec serializes snapshots. Defaults to GobSnapshotCodec.
+	SnapshotCodec SnapshotCodec
+}
+
+func (c *Config) store() Store {
+	if c.Store == nil {
+		c.Store = newMemoryStore()
+	}
+
+	return c.Store
+}
+
+func (c *Config) key(key string) string {
+	if c.KeyFunc == nil {
+		return key
+	}
+
+	return c.KeyFunc(key)
+}
+
+func (c *Config) snapshotCodec() SnapshotCodec {
+	if c.SnapshotCodec == nil {
+		c.SnapshotCodec = GobSnapshotCodec{}
+	}
+
+	return c.SnapshotCodec
+}
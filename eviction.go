@@ -0,0 +1,46 @@
+package lockotron
+
+// EvictionPolicy selects the algorithm Cache uses to pick a victim once
+// Config.MaxItems or Config.MaxBytes is exceeded.
+type EvictionPolicy int
+
+const (
+	// EvictionPolicyNone disables capacity-based eviction; items are only
+	// removed by explicit Delete or TTL expiry.
+	EvictionPolicyNone EvictionPolicy = iota
+	EvictionPolicyLRU
+	EvictionPolicyLFU
+	EvictionPolicyARC
+)
+
+type EvictReason int
+
+const (
+	EvictReasonCapacity EvictReason = iota
+	EvictReasonExpired
+)
+
+// evictionTracker is the bookkeeping side of an eviction policy. Cache
+// drives it: touch on every Get/Fetch hit, add on every Set, remove on
+// explicit Delete/expiry, and victim whenever capacity has been exceeded
+// and something has to go.
+type evictionTracker interface {
+	touch(key string)
+	add(key string)
+	remove(key string)
+	victim() (string, bool)
+	len() int
+}
+
+func newEvictionTracker(policy EvictionPolicy, capacity int) evictionTracker {
+	switch policy {
+	case EvictionPolicyLRU:
+		return newLRUTracker()
+	case EvictionPolicyLFU:
+		return newLFUTracker()
+	case EvictionPolicyARC:
+		return newARCTracker(capacity)
+	default:
+		return nil
+	}
+}
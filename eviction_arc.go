@@ -0,0 +1,185 @@
+package lockotron
+
+import (
+	"container/list"
+	"sync"
+)
+
+// arcTracker implements Adaptive Replacement Cache bookkeeping: T1/T2 hold
+// keys currently considered live (recently-seen-once / seen-again), B1/B2
+// are ghost lists of keys recently evicted from T1/T2 respectively, used
+// only to adapt the T1/T2 target size p. Ghost lists never cause a real
+// Store eviction by themselves; only victim() does, by moving a T1 or T2
+// entry into its ghost list.
+type arcTracker struct {
+	mutex sync.Mutex
+	cap   int
+	p     float64
+	t1    *list.List
+	t2    *list.List
+	b1    *list.List
+	b2    *list.List
+	elems map[string]*list.Element
+	where map[string]*list.List
+}
+
+func newARCTracker(capacity int) *arcTracker {
+	if capacity <= 0 {
+		capacity = 1
+	}
+
+	return &arcTracker{
+		cap:   capacity,
+		t1:    list.New(),
+		t2:    list.New(),
+		b1:    list.New(),
+		b2:    list.New(),
+		elems: make(map[string]*list.Element),
+		where: make(map[string]*list.List),
+	}
+}
+
+func (t *arcTracker) touch(key string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if l, ok := t.where[key]; !ok || (l != t.t1 && l != t.t2) {
+		return
+	}
+
+	t.promoteToT2(key)
+}
+
+func (t *arcTracker) promoteToT2(key string) {
+	e := t.elems[key]
+	l := t.where[key]
+	l.Remove(e)
+	t.elems[key] = t.t2.PushFront(key)
+	t.where[key] = t.t2
+}
+
+func (t *arcTracker) add(key string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	l, ok := t.where[key]
+	if ok && (l == t.t1 || l == t.t2) {
+		t.promoteToT2(key)
+		return
+	}
+
+	if ok && l == t.b1 {
+		ratio := float64(t.b2.Len()) / max(1, float64(t.b1.Len()))
+		t.p = min(float64(t.cap), t.p+max(1, ratio))
+		t.detach(key)
+		t.elems[key] = t.t2.PushFront(key)
+		t.where[key] = t.t2
+
+		return
+	}
+
+	if ok && l == t.b2 {
+		ratio := float64(t.b1.Len()) / max(1, float64(t.b2.Len()))
+		t.p = max(0, t.p-max(1, ratio))
+		t.detach(key)
+		t.elems[key] = t.t2.PushFront(key)
+		t.where[key] = t.t2
+
+		return
+	}
+
+	if t.t1.Len()+t.b1.Len() == t.cap && t.b1.Len() > 0 {
+		t.popBack(t.b1)
+	} else if t.t1.Len()+t.t2.Len()+t.b1.Len()+t.b2.Len() >= 2*t.cap && t.b2.Len() > 0 {
+		t.popBack(t.b2)
+	}
+
+	t.elems[key] = t.t1.PushFront(key)
+	t.where[key] = t.t1
+}
+
+func (t *arcTracker) detach(key string) {
+	if e, ok := t.elems[key]; ok {
+		t.where[key].Remove(e)
+	}
+	delete(t.elems, key)
+	delete(t.where, key)
+}
+
+func (t *arcTracker) popBack(l *list.List) {
+	e := l.Back()
+	if e == nil {
+		return
+	}
+
+	key := e.Value.(string)
+	l.Remove(e)
+	delete(t.elems, key)
+	delete(t.where, key)
+}
+
+func (t *arcTracker) remove(key string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.detach(key)
+}
+
+func (t *arcTracker) victim() (string, bool) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	var from, to *list.List
+	if float64(t.t1.Len()) > t.p || t.t2.Len() == 0 {
+		from, to = t.t1, t.b1
+	} else {
+		from, to = t.t2, t.b2
+	}
+
+	e := from.Back()
+	if e == nil {
+		from, to = t.t2, t.b2
+		e = from.Back()
+	}
+	if e == nil {
+		from, to = t.t1, t.b1
+		e = from.Back()
+	}
+	if e == nil {
+		return "", false
+	}
+
+	key := e.Value.(string)
+	from.Remove(e)
+	t.elems[key] = to.PushFront(key)
+	t.where[key] = to
+
+	if to.Len() > t.cap {
+		t.popBack(to)
+	}
+
+	return key, true
+}
+
+func (t *arcTracker) len() int {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	return t.t1.Len() + t.t2.Len()
+}
+
+func max(a, b float64) float64 {
+	if a > b {
+		return a
+	}
+
+	return b
+}
+
+func min(a, b float64) float64 {
+	if a < b {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,85 @@
+package lockotron
+
+import "testing"
+
+func TestARCTrackerGhostListSurvivesCapacityEviction(t *testing.T) {
+	cfg := &Config{
+		EvictionPolicy: EvictionPolicyARC,
+		MaxItems:       2,
+	}
+	c := NewCache(cfg)
+
+	c.Set("a", 1)
+	c.Set("b", 2)
+	// Cache is now at capacity; this Set evicts a victim into ARC's
+	// ghost list rather than removing it outright.
+	c.Set("c", 3)
+
+	tracker, ok := c.tracker.(*arcTracker)
+	if !ok {
+		t.Fatalf("expected *arcTracker, got %T", c.tracker)
+	}
+
+	tracker.mutex.Lock()
+	ghostLen := tracker.b1.Len() + tracker.b2.Len()
+	tracker.mutex.Unlock()
+
+	if ghostLen == 0 {
+		t.Fatalf("expected the evicted key to survive in a ghost list, got 0 ghost entries")
+	}
+}
+
+func TestARCTrackerBGhostHitAdaptsP(t *testing.T) {
+	tracker := newARCTracker(2)
+
+	tracker.add("a")
+	tracker.add("b")
+	key, ok := tracker.victim()
+	if !ok {
+		t.Fatalf("expected a victim")
+	}
+
+	tracker.mutex.Lock()
+	l := tracker.where[key]
+	ghost := l == tracker.b1 || l == tracker.b2
+	pBefore := tracker.p
+	tracker.mutex.Unlock()
+
+	if !ghost {
+		t.Fatalf("victim() should relocate the key into a ghost list, not remove it")
+	}
+
+	// Re-adding a key that's currently a ghost hit should adapt p and
+	// promote it back into T2, not just drop the ghost entry silently.
+	tracker.add(key)
+
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+
+	if tracker.where[key] != tracker.t2 {
+		t.Fatalf("expected ghost-hit key to be promoted into T2, got %v", tracker.where[key])
+	}
+	if tracker.p == pBefore {
+		t.Fatalf("expected p to adapt on a ghost-list hit, stayed at %v", pBefore)
+	}
+}
+
+func TestARCTrackerRemoveDropsGhostEntry(t *testing.T) {
+	tracker := newARCTracker(2)
+
+	tracker.add("a")
+	tracker.add("b")
+	key, ok := tracker.victim()
+	if !ok {
+		t.Fatalf("expected a victim")
+	}
+
+	tracker.remove(key)
+
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+
+	if _, ok := tracker.where[key]; ok {
+		t.Fatalf("expected remove() to drop the key entirely, including from its ghost list")
+	}
+}
@@ -0,0 +1,140 @@
+package lockotron
+
+import (
+	"container/list"
+	"sync"
+)
+
+// lfuTracker is the classic O(1) LFU algorithm: a list of frequency
+// buckets ordered ascending, each holding the set of keys currently at
+// that frequency.
+
+type lfuBucket struct {
+	freq int
+	keys map[string]struct{}
+}
+
+type lfuNode struct {
+	freq int
+	elem *list.Element
+}
+
+type lfuTracker struct {
+	mutex   sync.Mutex
+	buckets *list.List
+	nodes   map[string]*lfuNode
+}
+
+func newLFUTracker() *lfuTracker {
+	return &lfuTracker{buckets: list.New(), nodes: make(map[string]*lfuNode)}
+}
+
+func (t *lfuTracker) bucketAfter(after *list.Element, freq int) *list.Element {
+	var next *list.Element
+	if after == nil {
+		next = t.buckets.Front()
+	} else {
+		next = after.Next()
+	}
+
+	if next != nil && next.Value.(*lfuBucket).freq == freq {
+		return next
+	}
+
+	bucket := &lfuBucket{freq: freq, keys: make(map[string]struct{})}
+	if after == nil {
+		return t.buckets.PushFront(bucket)
+	}
+
+	return t.buckets.InsertAfter(bucket, after)
+}
+
+func (t *lfuTracker) touch(key string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.bumpLocked(key)
+}
+
+func (t *lfuTracker) add(key string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if _, ok := t.nodes[key]; ok {
+		t.bumpLocked(key)
+		return
+	}
+
+	elem := t.bucketAfter(nil, 1)
+	elem.Value.(*lfuBucket).keys[key] = struct{}{}
+	t.nodes[key] = &lfuNode{freq: 1, elem: elem}
+}
+
+func (t *lfuTracker) bumpLocked(key string) {
+	node, ok := t.nodes[key]
+	if !ok {
+		return
+	}
+
+	oldElem := node.elem
+	oldBucket := oldElem.Value.(*lfuBucket)
+	delete(oldBucket.keys, key)
+
+	newElem := t.bucketAfter(oldElem, node.freq+1)
+	newElem.Value.(*lfuBucket).keys[key] = struct{}{}
+
+	if len(oldBucket.keys) == 0 {
+		t.buckets.Remove(oldElem)
+	}
+
+	node.freq++
+	node.elem = newElem
+}
+
+func (t *lfuTracker) remove(key string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	node, ok := t.nodes[key]
+	if !ok {
+		return
+	}
+
+	bucket := node.elem.Value.(*lfuBucket)
+	delete(bucket.keys, key)
+	if len(bucket.keys) == 0 {
+		t.buckets.Remove(node.elem)
+	}
+
+	delete(t.nodes, key)
+}
+
+func (t *lfuTracker) victim() (string, bool) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	front := t.buckets.Front()
+	if front == nil {
+		return "", false
+	}
+
+	bucket := front.Value.(*lfuBucket)
+	for key := range bucket.keys {
+		delete(bucket.keys, key)
+		if len(bucket.keys) == 0 {
+			t.buckets.Remove(front)
+		}
+		delete(t.nodes, key)
+
+		return key, true
+	}
+
+	return "", false
+}
+
+func (t *lfuTracker) len() int {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	return len(t.nodes)
+}
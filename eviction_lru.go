@@ -0,0 +1,70 @@
+package lockotron
+
+import (
+	"container/list"
+	"sync"
+)
+
+type lruTracker struct {
+	mutex sync.Mutex
+	order *list.List
+	elems map[string]*list.Element
+}
+
+func newLRUTracker() *lruTracker {
+	return &lruTracker{order: list.New(), elems: make(map[string]*list.Element)}
+}
+
+func (t *lruTracker) touch(key string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if e, ok := t.elems[key]; ok {
+		t.order.MoveToFront(e)
+	}
+}
+
+func (t *lruTracker) add(key string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if e, ok := t.elems[key]; ok {
+		t.order.MoveToFront(e)
+		return
+	}
+
+	t.elems[key] = t.order.PushFront(key)
+}
+
+func (t *lruTracker) remove(key string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if e, ok := t.elems[key]; ok {
+		t.order.Remove(e)
+		delete(t.elems, key)
+	}
+}
+
+func (t *lruTracker) victim() (string, bool) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	e := t.order.Back()
+	if e == nil {
+		return "", false
+	}
+
+	key := e.Value.(string)
+	t.order.Remove(e)
+	delete(t.elems, key)
+
+	return key, true
+}
+
+func (t *lruTracker) len() int {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	return t.order.Len()
+}
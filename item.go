@@ -0,0 +1,31 @@
+package lockotron
+
+import "time"
+
+type item struct {
+	value    interface{}
+	ttl      int64
+	negative bool
+}
+
+func newItem(value interface{}, ttl time.Duration) *item {
+	it := &item{value: value}
+	if ttl > 0 {
+		it.ttl = time.Now().Add(ttl).UnixNano()
+	}
+
+	return it
+}
+
+func newNegativeItem(ttl time.Duration) *item {
+	it := &item{negative: true}
+	if ttl > 0 {
+		it.ttl = time.Now().Add(ttl).UnixNano()
+	}
+
+	return it
+}
+
+func (i *item) isExpirable() bool {
+	return i.ttl > 0
+}
@@ -0,0 +1,141 @@
+package lockotron
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// pollInterval is the default wait granularity for a waiter blocked on
+// lockEntry.acquire when Config.LockLeaseInterval is unset.
+const pollInterval = 50 * time.Millisecond
+
+// lockEntry is a per-key lease lock: at most one goroutine holds it at a
+// time. A holder's lease is granted once, for up to leaseTimeout, and is
+// never extended — if the fallback it guards is still running once the
+// lease expires, the next waiter forcibly takes the lock over instead of
+// blocking forever, even if the original holder is merely slow rather
+// than dead. Each successful acquire bumps generation; release must
+// present the generation it was granted so a holder displaced by a steal
+// can't clobber the new holder's lock state with a stale release.
+type lockEntry struct {
+	mutex      sync.Mutex
+	count      int
+	locked     bool
+	generation uint64
+	expiresAt  time.Time
+	waitCh     chan struct{}
+}
+
+func newLockEntry() *lockEntry {
+	return &lockEntry{waitCh: make(chan struct{})}
+}
+
+// acquire blocks until the lock is free, its lease has expired, or ctx is
+// done. On success it returns the generation token the caller must pass
+// to release, so a later steal can't be undone by a stale release.
+func (e *lockEntry) acquire(ctx context.Context, leaseTimeout, pollEvery time.Duration) (uint64, error) {
+	if pollEvery <= 0 {
+		pollEvery = pollInterval
+	}
+
+	for {
+		e.mutex.Lock()
+
+		expired := e.locked && leaseTimeout > 0 && !e.expiresAt.IsZero() && time.Now().After(e.expiresAt)
+		if !e.locked || expired {
+			e.locked = true
+			e.generation++
+			if leaseTimeout > 0 {
+				e.expiresAt = time.Now().Add(leaseTimeout)
+			} else {
+				e.expiresAt = time.Time{}
+			}
+			gen := e.generation
+			e.mutex.Unlock()
+
+			return gen, nil
+		}
+
+		ch := e.waitCh
+		e.mutex.Unlock()
+
+		select {
+		case <-ch:
+		case <-time.After(pollEvery):
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+	}
+}
+
+// stillOwns reports whether generation is still the current holder's,
+// i.e. no one has stolen the lease since it was granted. Callers that do
+// work outside the lock (like writing a fallback's result back to the
+// store) should check this before publishing it, since a stolen lease
+// means a later holder may already have written a fresher value.
+func (e *lockEntry) stillOwns(generation uint64) bool {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	return e.generation == generation
+}
+
+// release frees the lock, but only if generation still matches the
+// current holder. A holder whose lease was stolen out from under it
+// holds a stale generation, so its eventual release is a no-op rather
+// than corrupting the new holder's in-progress lock.
+func (e *lockEntry) release(generation uint64) {
+	e.mutex.Lock()
+	if e.generation != generation {
+		e.mutex.Unlock()
+
+		return
+	}
+
+	e.locked = false
+	e.expiresAt = time.Time{}
+	ch := e.waitCh
+	e.waitCh = make(chan struct{})
+	e.mutex.Unlock()
+
+	close(ch)
+}
+
+type locker struct {
+	mutex   sync.Mutex
+	entries map[string]*lockEntry
+}
+
+func newLocker() *locker {
+	return &locker{entries: make(map[string]*lockEntry)}
+}
+
+func (l *locker) obtain(key string) *lockEntry {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	e, ok := l.entries[key]
+	if !ok {
+		e = newLockEntry()
+		l.entries[key] = e
+	}
+	e.count++
+
+	return e
+}
+
+func (l *locker) release(key string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	e, ok := l.entries[key]
+	if !ok {
+		return
+	}
+
+	e.count--
+	if e.count <= 0 {
+		delete(l.entries, key)
+	}
+}
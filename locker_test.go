@@ -0,0 +1,86 @@
+package lockotron
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestLockEntryAcquireRespectsContextCancellation(t *testing.T) {
+	e := newLockEntry()
+
+	if _, err := e.acquire(context.Background(), 0, 0); err != nil {
+		t.Fatalf("first acquire: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if _, err := e.acquire(ctx, 0, time.Millisecond); err == nil {
+		t.Fatalf("expected second acquire to fail once ctx is done, held lock forever")
+	}
+}
+
+func TestLockEntryLeaseExpiresWithoutRenewal(t *testing.T) {
+	e := newLockEntry()
+
+	if _, err := e.acquire(context.Background(), 30*time.Millisecond, time.Millisecond); err != nil {
+		t.Fatalf("first acquire: %v", err)
+	}
+
+	// The first holder never releases (simulating a hung fallback); a
+	// second waiter must still be able to steal the lease once it
+	// expires, since leases are no longer renewed by a background timer.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	gen2, err := e.acquire(ctx, 30*time.Millisecond, time.Millisecond)
+	if err != nil {
+		t.Fatalf("expected steal to succeed once lease expired, got %v", err)
+	}
+	if gen2 <= 1 {
+		t.Fatalf("expected steal to bump the generation, got %d", gen2)
+	}
+}
+
+func TestLockEntryStaleReleaseDoesNotClobberNewHolder(t *testing.T) {
+	e := newLockEntry()
+
+	gen1, err := e.acquire(context.Background(), 10*time.Millisecond, time.Millisecond)
+	if err != nil {
+		t.Fatalf("first acquire: %v", err)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	gen2, err := e.acquire(ctx, 10*time.Millisecond, time.Millisecond)
+	if err != nil {
+		t.Fatalf("steal acquire: %v", err)
+	}
+
+	// The original (displaced) holder's release must be a no-op: it
+	// should not free a lock the second holder still legitimately owns.
+	e.release(gen1)
+
+	e.mutex.Lock()
+	stillLocked := e.locked
+	stillGen2 := e.generation == gen2
+	e.mutex.Unlock()
+
+	if !stillLocked || !stillGen2 {
+		t.Fatalf("stale release from a displaced holder corrupted the current holder's lock state")
+	}
+
+	e.release(gen2)
+
+	e.mutex.Lock()
+	locked := e.locked
+	e.mutex.Unlock()
+
+	if locked {
+		t.Fatalf("expected the legitimate holder's release to free the lock")
+	}
+}
@@ -0,0 +1,27 @@
+// Package lockotronmsgpack provides a msgpack lockotron.SnapshotCodec,
+// kept out of the core module so lockotron itself stays dependency-free.
+package lockotronmsgpack
+
+import (
+	"io"
+
+	"lockotron"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// Codec implements lockotron.SnapshotCodec using msgpack.
+type Codec struct{}
+
+func (Codec) Encode(w io.Writer, entries []lockotron.SnapshotEntry) error {
+	return msgpack.NewEncoder(w).Encode(entries)
+}
+
+func (Codec) Decode(r io.Reader) ([]lockotron.SnapshotEntry, error) {
+	var entries []lockotron.SnapshotEntry
+	if err := msgpack.NewDecoder(r).Decode(&entries); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,107 @@
+// Package lockotronprom bridges a lockotron.Cache's Stats to Prometheus,
+// without pulling the client library into the core module.
+package lockotronprom
+
+import (
+	"lockotron"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Collector implements prometheus.Collector over a lockotron.Cache,
+// translating Cache.Stats() into gauges/counters on every scrape.
+type Collector struct {
+	cache *lockotron.Cache
+
+	hits                *prometheus.Desc
+	misses              *prometheus.Desc
+	fallbackCalls       *prometheus.Desc
+	fallbackErrors      *prometheus.Desc
+	coalescedWaits      *prometheus.Desc
+	evictionsByCapacity *prometheus.Desc
+	itemCount           *prometheus.Desc
+	fallbackLatency     *prometheus.Desc
+}
+
+// NewCollector wraps cache for Prometheus registration, e.g.
+// prometheus.MustRegister(lockotronprom.NewCollector(cache, "orders")).
+func NewCollector(cache *lockotron.Cache, name string) *Collector {
+	labels := prometheus.Labels{"cache": name}
+	namespace := "lockotron"
+
+	return &Collector{
+		cache: cache,
+		hits: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "hits_total"),
+			"Total Cache.Get/Fetch calls served from the store.",
+			nil, labels,
+		),
+		misses: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "misses_total"),
+			"Total Cache.Get/Fetch lookups that found nothing cached; a Fetch that has to take the key's lock looks up twice.",
+			nil, labels,
+		),
+		fallbackCalls: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "fallback_calls_total"),
+			"Total Fetch fallback invocations.",
+			nil, labels,
+		),
+		fallbackErrors: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "fallback_errors_total"),
+			"Total Fetch fallback invocations that returned an error.",
+			nil, labels,
+		),
+		coalescedWaits: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "coalesced_waits_total"),
+			"Total Fetch calls that waited on another goroutine's fallback instead of running their own.",
+			nil, labels,
+		),
+		evictionsByCapacity: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "evictions_total"),
+			"Total items evicted, by reason.",
+			[]string{"reason"}, labels,
+		),
+		itemCount: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "items"),
+			"Current number of items in the Cache.",
+			nil, labels,
+		),
+		fallbackLatency: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "fallback_duration_seconds"),
+			"Fetch fallback latency distribution.",
+			nil, labels,
+		),
+	}
+}
+
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.hits
+	ch <- c.misses
+	ch <- c.fallbackCalls
+	ch <- c.fallbackErrors
+	ch <- c.coalescedWaits
+	ch <- c.evictionsByCapacity
+	ch <- c.itemCount
+	ch <- c.fallbackLatency
+}
+
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.cache.Stats()
+
+	ch <- prometheus.MustNewConstMetric(c.hits, prometheus.CounterValue, float64(stats.Hits))
+	ch <- prometheus.MustNewConstMetric(c.misses, prometheus.CounterValue, float64(stats.Misses))
+	ch <- prometheus.MustNewConstMetric(c.fallbackCalls, prometheus.CounterValue, float64(stats.FallbackCalls))
+	ch <- prometheus.MustNewConstMetric(c.fallbackErrors, prometheus.CounterValue, float64(stats.FallbackErrors))
+	ch <- prometheus.MustNewConstMetric(c.coalescedWaits, prometheus.CounterValue, float64(stats.CoalescedWaits))
+	ch <- prometheus.MustNewConstMetric(c.evictionsByCapacity, prometheus.CounterValue, float64(stats.EvictionsByCapacity), "capacity")
+	ch <- prometheus.MustNewConstMetric(c.evictionsByCapacity, prometheus.CounterValue, float64(stats.EvictionsByExpiry), "expired")
+	ch <- prometheus.MustNewConstMetric(c.itemCount, prometheus.GaugeValue, float64(stats.ItemCount))
+
+	buckets := make(map[float64]uint64, len(stats.FallbackLatency.Buckets))
+	for bound, count := range stats.FallbackLatency.Buckets {
+		buckets[bound] = count
+	}
+	ch <- prometheus.MustNewConstHistogram(
+		c.fallbackLatency, stats.FallbackLatency.Count, stats.FallbackLatency.Sum, buckets,
+	)
+}
@@ -0,0 +1,59 @@
+package lockotron
+
+import "sync"
+
+type memoryStore struct {
+	mutex sync.RWMutex
+	items map[string]*item
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{items: make(map[string]*item)}
+}
+
+func (s *memoryStore) Get(key string) (*item, bool, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	it, ok := s.items[key]
+
+	return it, ok, nil
+}
+
+func (s *memoryStore) Set(key string, it *item) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.items[key] = it
+
+	return nil
+}
+
+func (s *memoryStore) Delete(key string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	delete(s.items, key)
+
+	return nil
+}
+
+func (s *memoryStore) Iterate(fn func(key string, it *item) bool) error {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	for key, it := range s.items {
+		if !fn(key, it) {
+			break
+		}
+	}
+
+	return nil
+}
+
+func (s *memoryStore) Len() (int, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return len(s.items), nil
+}
@@ -0,0 +1,133 @@
+package lockotron
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"io"
+	"os"
+	"time"
+)
+
+// SnapshotEntry is one cached item as persisted by SaveSnapshot/LoadSnapshot.
+type SnapshotEntry struct {
+	Key   string
+	TTL   int64 // absolute UnixNano expiry, 0 = no expiry
+	Value interface{}
+}
+
+// SnapshotCodec serializes the full set of snapshot entries for
+// Cache.SaveSnapshot/LoadSnapshot. The default is GobSnapshotCodec;
+// JSONSnapshotCodec is also built in, and the lockotronmsgpack subpackage
+// provides a msgpack option without pulling that dependency into core.
+type SnapshotCodec interface {
+	Encode(w io.Writer, entries []SnapshotEntry) error
+	Decode(r io.Reader) ([]SnapshotEntry, error)
+}
+
+// GobSnapshotCodec uses encoding/gob. Concrete value types stored in the
+// Cache must be registered with gob.Register beforehand.
+type GobSnapshotCodec struct{}
+
+func (GobSnapshotCodec) Encode(w io.Writer, entries []SnapshotEntry) error {
+	return gob.NewEncoder(w).Encode(entries)
+}
+
+func (GobSnapshotCodec) Decode(r io.Reader) ([]SnapshotEntry, error) {
+	var entries []SnapshotEntry
+	if err := gob.NewDecoder(r).Decode(&entries); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// JSONSnapshotCodec uses encoding/json. Values decode as the generic types
+// encoding/json produces (e.g. float64 for numbers), not their original
+// Go type.
+type JSONSnapshotCodec struct{}
+
+func (JSONSnapshotCodec) Encode(w io.Writer, entries []SnapshotEntry) error {
+	return json.NewEncoder(w).Encode(entries)
+}
+
+func (JSONSnapshotCodec) Decode(r io.Reader) ([]SnapshotEntry, error) {
+	var entries []SnapshotEntry
+	if err := json.NewDecoder(r).Decode(&entries); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// SaveSnapshot writes every non-expired item to w using Config.SnapshotCodec
+// (GobSnapshotCodec by default). Negative-cache placeholders are not written.
+func (c *Cache) SaveSnapshot(w io.Writer) error {
+	now := time.Now().UnixNano()
+	entries := make([]SnapshotEntry, 0)
+
+	_ = c.store.Iterate(func(key string, it *item) bool {
+		if it.negative || (it.isExpirable() && now > it.ttl) {
+			return true
+		}
+
+		entries = append(entries, SnapshotEntry{Key: key, TTL: it.ttl, Value: it.value})
+
+		return true
+	})
+
+	return c.config.snapshotCodec().Encode(w, entries)
+}
+
+// LoadSnapshot hydrates the Cache from r, skipping entries that have
+// already expired. It does not clear existing items first.
+func (c *Cache) LoadSnapshot(r io.Reader) error {
+	entries, err := c.config.snapshotCodec().Decode(r)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UnixNano()
+
+	for _, entry := range entries {
+		if entry.TTL > 0 && now > entry.TTL {
+			continue
+		}
+
+		it := &item{value: entry.Value, ttl: entry.TTL}
+
+		_ = c.store.Set(entry.Key, it)
+		c.remember(entry.Key, entry.Value)
+
+		if c.tracker != nil {
+			c.tracker.add(entry.Key)
+		}
+	}
+
+	return nil
+}
+
+func (c *Cache) saveSnapshotToPath() {
+	if c.config.SnapshotPath == "" {
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := c.SaveSnapshot(&buf); err != nil {
+		return
+	}
+
+	_ = writeFileAtomic(c.config.SnapshotPath, buf.Bytes())
+}
+
+// writeFileAtomic writes data to a temp file in the same directory and
+// renames it into place, so a crash mid-write never leaves a truncated
+// snapshot behind.
+func writeFileAtomic(path string, data []byte) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, path)
+}
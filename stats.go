@@ -0,0 +1,113 @@
+package lockotron
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Observer lets callers bridge Cache activity to Prometheus, OpenTelemetry,
+// logs, or anything else without the core module importing those deps.
+// See the lockotronprom subpackage for a ready-made Prometheus Collector.
+type Observer interface {
+	OnHit(key string)
+	OnMiss(key string)
+	OnFallback(duration time.Duration, err error)
+	OnEvict(key string, value interface{}, reason EvictReason)
+}
+
+// Stats is a point-in-time snapshot of a Cache's activity counters.
+type Stats struct {
+	Hits                uint64
+	Misses              uint64
+	FallbackCalls       uint64
+	FallbackErrors      uint64
+	CoalescedWaits      uint64
+	EvictionsByCapacity uint64
+	EvictionsByExpiry   uint64
+	ItemCount           int
+	FallbackLatency     Histogram
+}
+
+// Histogram is a cumulative, Prometheus-style latency histogram: Buckets
+// maps each bucket's upper bound (seconds) to the number of observations
+// less than or equal to it.
+type Histogram struct {
+	Buckets map[float64]uint64
+	Sum     float64
+	Count   uint64
+}
+
+var defaultLatencyBuckets = []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5}
+
+type histogram struct {
+	mutex   sync.Mutex
+	buckets []float64
+	counts  []uint64
+	sum     float64
+	count   uint64
+}
+
+func newHistogram(buckets []float64) *histogram {
+	return &histogram{buckets: buckets, counts: make([]uint64, len(buckets))}
+}
+
+func (h *histogram) observe(seconds float64) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.sum += seconds
+	h.count++
+
+	for i, bound := range h.buckets {
+		if seconds <= bound {
+			h.counts[i]++
+		}
+	}
+}
+
+func (h *histogram) snapshot() Histogram {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	buckets := make(map[float64]uint64, len(h.buckets))
+	for i, bound := range h.buckets {
+		buckets[bound] = h.counts[i]
+	}
+
+	return Histogram{Buckets: buckets, Sum: h.sum, Count: h.count}
+}
+
+type counters struct {
+	hits, misses                  uint64
+	fallbackCalls, fallbackErrors uint64
+	coalescedWaits                uint64
+	evictionsByCapacity           uint64
+	evictionsByExpiry             uint64
+}
+
+func (c *counters) hit()           { atomic.AddUint64(&c.hits, 1) }
+func (c *counters) miss()          { atomic.AddUint64(&c.misses, 1) }
+func (c *counters) fallbackCall()  { atomic.AddUint64(&c.fallbackCalls, 1) }
+func (c *counters) fallbackError() { atomic.AddUint64(&c.fallbackErrors, 1) }
+func (c *counters) coalescedWait() { atomic.AddUint64(&c.coalescedWaits, 1) }
+
+func (c *counters) evicted(reason EvictReason) {
+	if reason == EvictReasonExpired {
+		atomic.AddUint64(&c.evictionsByExpiry, 1)
+	} else {
+		atomic.AddUint64(&c.evictionsByCapacity, 1)
+	}
+}
+
+func (c *counters) snapshot() Stats {
+	return Stats{
+		Hits:                atomic.LoadUint64(&c.hits),
+		Misses:              atomic.LoadUint64(&c.misses),
+		FallbackCalls:       atomic.LoadUint64(&c.fallbackCalls),
+		FallbackErrors:      atomic.LoadUint64(&c.fallbackErrors),
+		CoalescedWaits:      atomic.LoadUint64(&c.coalescedWaits),
+		EvictionsByCapacity: atomic.LoadUint64(&c.evictionsByCapacity),
+		EvictionsByExpiry:   atomic.LoadUint64(&c.evictionsByExpiry),
+	}
+}
@@ -0,0 +1,14 @@
+package lockotron
+
+// Store is the storage backend behind a Cache. Cache itself owns TTL
+// bookkeeping and the singleflight-style locking used by Fetch/FetchEx;
+// a Store only has to hold and hand back items for a key.
+//
+// Implementations must be safe for concurrent use.
+type Store interface {
+	Get(key string) (*item, bool, error)
+	Set(key string, it *item) error
+	Delete(key string) error
+	Iterate(fn func(key string, it *item) bool) error
+	Len() (int, error)
+}
@@ -0,0 +1,150 @@
+package lockotron
+
+import (
+	"encoding/json"
+	"sync"
+)
+
+// MemcachedClient is the minimal surface lockotron needs from a Memcached
+// client, satisfied by a thin adapter over github.com/bradfitz/gomemcache
+// (or any other client), keeping lockotron free of a hard dependency.
+type MemcachedClient interface {
+	Get(key string) ([]byte, error)
+	Set(key string, value []byte, expireSeconds int32) error
+	Delete(key string) error
+}
+
+type memcachedEnvelope struct {
+	Value    []byte
+	TTL      int64
+	Negative bool
+}
+
+type MemcachedStore struct {
+	client MemcachedClient
+	codec  Codec
+	keys   *memoryKeyIndex
+}
+
+func NewMemcachedStore(client MemcachedClient, codec Codec) *MemcachedStore {
+	if codec == nil {
+		codec = JSONCodec{}
+	}
+
+	return &MemcachedStore{client: client, codec: codec, keys: newMemoryKeyIndex()}
+}
+
+func (s *MemcachedStore) Get(key string) (*item, bool, error) {
+	data, err := s.client.Get(key)
+	if err != nil {
+		return nil, false, err
+	}
+	if data == nil {
+		return nil, false, nil
+	}
+
+	var env memcachedEnvelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return nil, false, err
+	}
+
+	value, err := s.codec.Decode(env.Value)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return &item{value: value, ttl: env.TTL, negative: env.Negative}, true, nil
+}
+
+func (s *MemcachedStore) Set(key string, it *item) error {
+	valueBytes, err := s.codec.Encode(it.value)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(memcachedEnvelope{Value: valueBytes, TTL: it.ttl, Negative: it.negative})
+	if err != nil {
+		return err
+	}
+
+	if err := s.client.Set(key, data, int32(remaining(it.ttl).Seconds())); err != nil {
+		return err
+	}
+
+	s.keys.add(key)
+
+	return nil
+}
+
+func (s *MemcachedStore) Delete(key string) error {
+	s.keys.remove(key)
+
+	return s.client.Delete(key)
+}
+
+// Iterate and Len rely on a local key index since Memcached exposes no
+// native key-listing command; it only ever reflects keys this process has
+// written.
+func (s *MemcachedStore) Iterate(fn func(key string, it *item) bool) error {
+	for _, key := range s.keys.list() {
+		it, ok, err := s.Get(key)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			s.keys.remove(key)
+			continue
+		}
+		if !fn(key, it) {
+			break
+		}
+	}
+
+	return nil
+}
+
+func (s *MemcachedStore) Len() (int, error) {
+	return s.keys.size(), nil
+}
+
+type memoryKeyIndex struct {
+	mutex sync.Mutex
+	keys  map[string]struct{}
+}
+
+func newMemoryKeyIndex() *memoryKeyIndex {
+	return &memoryKeyIndex{keys: make(map[string]struct{})}
+}
+
+func (k *memoryKeyIndex) add(key string) {
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+
+	k.keys[key] = struct{}{}
+}
+
+func (k *memoryKeyIndex) remove(key string) {
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+
+	delete(k.keys, key)
+}
+
+func (k *memoryKeyIndex) list() []string {
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+
+	keys := make([]string, 0, len(k.keys))
+	for key := range k.keys {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
+
+func (k *memoryKeyIndex) size() int {
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+
+	return len(k.keys)
+}
@@ -0,0 +1,120 @@
+package lockotron
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// RedisClient is the minimal surface lockotron needs from a Redis client.
+// It is satisfied by a thin adapter over github.com/redis/go-redis (or any
+// other client), which keeps lockotron itself free of a hard Redis
+// dependency.
+type RedisClient interface {
+	Get(key string) ([]byte, error)
+	Set(key string, value []byte, ttl time.Duration) error
+	Del(key string) error
+	Keys(pattern string) ([]string, error)
+}
+
+type redisEnvelope struct {
+	Value    []byte
+	TTL      int64
+	Negative bool
+}
+
+type RedisStore struct {
+	client RedisClient
+	codec  Codec
+}
+
+func NewRedisStore(client RedisClient, codec Codec) *RedisStore {
+	if codec == nil {
+		codec = JSONCodec{}
+	}
+
+	return &RedisStore{client: client, codec: codec}
+}
+
+func (s *RedisStore) Get(key string) (*item, bool, error) {
+	data, err := s.client.Get(key)
+	if err != nil {
+		return nil, false, err
+	}
+	if data == nil {
+		return nil, false, nil
+	}
+
+	var env redisEnvelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return nil, false, err
+	}
+
+	value, err := s.codec.Decode(env.Value)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return &item{value: value, ttl: env.TTL, negative: env.Negative}, true, nil
+}
+
+func (s *RedisStore) Set(key string, it *item) error {
+	valueBytes, err := s.codec.Encode(it.value)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(redisEnvelope{Value: valueBytes, TTL: it.ttl, Negative: it.negative})
+	if err != nil {
+		return err
+	}
+
+	return s.client.Set(key, data, remaining(it.ttl))
+}
+
+func (s *RedisStore) Delete(key string) error {
+	return s.client.Del(key)
+}
+
+func (s *RedisStore) Iterate(fn func(key string, it *item) bool) error {
+	keys, err := s.client.Keys("*")
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		it, ok, err := s.Get(key)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		if !fn(key, it) {
+			break
+		}
+	}
+
+	return nil
+}
+
+func (s *RedisStore) Len() (int, error) {
+	keys, err := s.client.Keys("*")
+	if err != nil {
+		return 0, err
+	}
+
+	return len(keys), nil
+}
+
+func remaining(absoluteTTL int64) time.Duration {
+	if absoluteTTL == 0 {
+		return 0
+	}
+
+	d := time.Until(time.Unix(0, absoluteTTL))
+	if d < 0 {
+		return 0
+	}
+
+	return d
+}
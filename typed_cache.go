@@ -0,0 +1,90 @@
+package lockotron
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// TypedCache wraps Cache with a generics-based API so callers get Get/Set/
+// Fetch signatures typed in K and V instead of interface{}, without
+// type assertions of their own. Values are still stored as interface{}
+// and asserted back to V inside every Get/Fetch. It reuses the same
+// locker/eviction/store machinery as Cache; the underlying storage key
+// remains a string, produced from K via keyFunc.
+type TypedCache[K comparable, V any] struct {
+	cache   *Cache
+	keyFunc func(K) string
+}
+
+// NewTypedCache creates a TypedCache backed by a fresh Cache built from
+// config. keyFunc encodes K as the string key lockotron stores under; if
+// nil, it defaults to fmt.Sprintf("%v", key).
+func NewTypedCache[K comparable, V any](config *Config, keyFunc func(K) string) *TypedCache[K, V] {
+	if keyFunc == nil {
+		keyFunc = func(key K) string { return fmt.Sprintf("%v", key) }
+	}
+
+	return &TypedCache[K, V]{cache: NewCache(config), keyFunc: keyFunc}
+}
+
+func (t *TypedCache[K, V]) Close() error {
+	return t.cache.Close()
+}
+
+func (t *TypedCache[K, V]) Set(key K, value V) {
+	t.cache.Set(t.keyFunc(key), value)
+}
+
+func (t *TypedCache[K, V]) SetEx(key K, ttl time.Duration, value V) {
+	t.cache.SetEx(t.keyFunc(key), ttl, value)
+}
+
+func (t *TypedCache[K, V]) Get(key K) (V, error) {
+	value, err := t.cache.Get(t.keyFunc(key))
+
+	return t.cast(key, value, err)
+}
+
+func (t *TypedCache[K, V]) Delete(key K) {
+	t.cache.Delete(t.keyFunc(key))
+}
+
+func (t *TypedCache[K, V]) DeleteAll() {
+	t.cache.DeleteAll()
+}
+
+func (t *TypedCache[K, V]) Fetch(ctx context.Context, key K, fallback func(K) (V, error)) (V, error) {
+	return t.fetch(ctx, key, t.cache.config.DefaultTTL, fallback)
+}
+
+func (t *TypedCache[K, V]) FetchEx(ctx context.Context, key K, ttl time.Duration, fallback func(K) (V, error)) (V, error) {
+	return t.fetch(ctx, key, ttl, fallback)
+}
+
+func (t *TypedCache[K, V]) fetch(ctx context.Context, key K, ttl time.Duration, fallback func(K) (V, error)) (V, error) {
+	value, err := t.cache.FetchEx(ctx, t.keyFunc(key), ttl, func(string) (interface{}, error) {
+		return fallback(key)
+	})
+
+	return t.cast(key, value, err)
+}
+
+func (t *TypedCache[K, V]) Stats() Stats {
+	return t.cache.Stats()
+}
+
+func (t *TypedCache[K, V]) cast(key K, value interface{}, err error) (V, error) {
+	var zero V
+
+	if err != nil {
+		return zero, err
+	}
+
+	typed, ok := value.(V)
+	if !ok {
+		return zero, fmt.Errorf("lockotron: value for key %v is %T, not %T", key, value, zero)
+	}
+
+	return typed, nil
+}